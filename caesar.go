@@ -0,0 +1,42 @@
+package vigenere
+
+// Caesar is a classical Caesar cipher: every plaintext character is shifted by the same
+// constant amount, equivalent to a repeating-key Vigenère cipher whose key is a single
+// character. It uses the default A-Z alphabet, mirroring the `NewCaesar`/`NewShift` pair from
+// the exercism simple-cipher exercise collapsed into a single constructor.
+type Caesar struct {
+	v     *Vigenere
+	shift int64
+}
+
+// NewCaesarCipher creates a new Caesar cipher that shifts every plaintext character by
+// `shift` positions over the default alphabet. Negative shifts, and shifts wider than the
+// alphabet, are taken modulo the alphabet's length.
+func NewCaesarCipher(shift int64) (*Caesar, error) {
+	v, err := NewVigenere(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Caesar{v: v, shift: shift}, nil
+}
+
+// key returns the single-character repeating key equivalent to this Caesar's shift.
+func (c *Caesar) key() string {
+	shift := c.shift % c.v.Length
+	if shift < 0 {
+		shift += c.v.Length
+	}
+
+	return c.v.Alphabets[shift]
+}
+
+// Encrypt encrypts plaintext by shifting every character by this Caesar's configured shift.
+func (c *Caesar) Encrypt(plaintext string) (string, error) {
+	return c.v.EncryptRepeating(plaintext, c.key())
+}
+
+// Decrypt decrypts ciphertext that was encrypted with the same shift.
+func (c *Caesar) Decrypt(ciphertext string) (string, error) {
+	return c.v.DecryptRepeating(ciphertext, c.key())
+}
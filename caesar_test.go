@@ -0,0 +1,70 @@
+package vigenere
+
+import "testing"
+
+func TestCaesarEncryptDecrypt(t *testing.T) {
+	tests := []struct {
+		name           string
+		shift          int64
+		plaintext      string
+		expectedOutput string
+	}{
+		{
+			name:           "test_success_caesar_shift_3",
+			shift:          3,
+			plaintext:      "ATTACKATDAWN",
+			expectedOutput: "DWWDFNDWGDZQ",
+		},
+		{
+			name:           "test_success_caesar_negative_shift",
+			shift:          -3,
+			plaintext:      "DWWDFNDWGDZQ",
+			expectedOutput: "ATTACKATDAWN",
+		},
+		{
+			name:           "test_success_caesar_shift_wider_than_alphabet",
+			shift:          29,
+			plaintext:      "ATTACKATDAWN",
+			expectedOutput: "DWWDFNDWGDZQ",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			caesar, err := NewCaesarCipher(tc.shift)
+			if err != nil {
+				t.Fatalf("NewCaesarCipher should not return an error. Got: %v.", err.Error())
+			}
+
+			result, err := caesar.Encrypt(tc.plaintext)
+			if err != nil {
+				t.Fatalf("Encrypt should not return an error. Got: %v.", err.Error())
+			}
+
+			if result != tc.expectedOutput {
+				t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", tc.expectedOutput, result)
+			}
+		})
+	}
+}
+
+func TestCaesarRoundTrip(t *testing.T) {
+	caesar, err := NewCaesarCipher(7)
+	if err != nil {
+		t.Fatalf("NewCaesarCipher should not return an error. Got: %v.", err.Error())
+	}
+
+	ciphertext, err := caesar.Encrypt("VIGENERECIPHER")
+	if err != nil {
+		t.Fatalf("Encrypt should not return an error. Got: %v.", err.Error())
+	}
+
+	plaintext, err := caesar.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt should not return an error. Got: %v.", err.Error())
+	}
+
+	if plaintext != "VIGENERECIPHER" {
+		t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", "VIGENERECIPHER", plaintext)
+	}
+}
@@ -0,0 +1,309 @@
+// Package cryptanalysis implements classical ciphertext-only attacks against the repeating-key
+// Vigenère cipher: Kasiski examination and Friedman's index of coincidence to recover the
+// probable key length, then a per-coset chi-squared frequency match to recover the key itself.
+// Everything here operates purely on a ciphertext and a `*vigenere.Vigenere`'s `Alphabets`, in
+// contrast to the parent package's one-time-pad `Encrypt`/`Decrypt`, which require a secret
+// already as long as the plaintext.
+//
+// `GuessKeyLength` and `RecoverKey` are methods on an `Analyzer` rather than directly on
+// `*vigenere.Vigenere`: a `LanguageProfile` and a precomputed single-rune alphabet set are
+// needed on every call, and an `Analyzer` holds those once per ciphertext-analysis session
+// instead of threading them through every call or bloating `*vigenere.Vigenere` with fields
+// only this package needs.
+//
+// Only single-rune alphabets are supported (e.g. the default A-Z): the Kasiski, Friedman, and
+// chi-squared statistics below all assume one ciphertext rune is one alphabet symbol, which
+// breaks down for the multi-rune entries that both `vigenere.NewVigenere` and
+// `vigenere.NewVigenereUnicode` allow. `NewAnalyzer` rejects such alphabets.
+package cryptanalysis
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lauslim12/vigenere"
+)
+
+// errMultiRuneAlphabet is returned by `NewAnalyzer` if `v`'s alphabet contains any entry
+// spanning more than one rune.
+var errMultiRuneAlphabet = errors.New("NewAnalyzer: cryptanalysis only supports single-rune alphabets")
+
+// minKasiskiNGram and maxKasiskiNGram bound the repeated-substring lengths examined during
+// Kasiski examination: trigrams and quadgrams are long enough to rarely repeat by chance in
+// ordinary text, but short enough to actually recur in ciphertexts of a few hundred characters.
+const (
+	minKasiskiNGram = 3
+	maxKasiskiNGram = 4
+)
+
+// LanguageProfile describes the statistical fingerprint of a natural language over a particular
+// alphabet: the expected letter frequencies used by `RecoverKey`'s chi-squared test, and the
+// expected index of coincidence used by `GuessKeyLength`'s Friedman test. Use `EnglishProfile`
+// for the default A-Z alphabet, or build your own for a different language or alphabet.
+type LanguageProfile struct {
+	// Frequencies maps each alphabet entry (e.g. "A") to its expected relative frequency in
+	// ordinary plaintext.
+	Frequencies map[string]float64
+
+	// TargetIoC is the expected index of coincidence for this language over this alphabet.
+	// English over A-Z is approximately 0.0667; a uniformly random alphabet of size N has an
+	// index of coincidence of roughly 1/N.
+	TargetIoC float64
+}
+
+// Analyzer recovers the probable key length and key of a ciphertext produced by a
+// repeating-key Vigenère cipher, using only the ciphertext itself. It operates over the same
+// `Alphabets` as the `*vigenere.Vigenere` it wraps, not hardcoded A-Z.
+type Analyzer struct {
+	v        *vigenere.Vigenere
+	profile  LanguageProfile
+	alphabet map[rune]bool
+}
+
+// NewAnalyzer creates an `Analyzer` for `v`'s alphabet, scoring candidate key lengths and keys
+// against `profile`. Returns `errMultiRuneAlphabet` if any entry in `v.Alphabets` spans more
+// than one rune.
+func NewAnalyzer(v *vigenere.Vigenere, profile LanguageProfile) (*Analyzer, error) {
+	alphabet := make(map[rune]bool, len(v.Alphabets))
+	for _, a := range v.Alphabets {
+		runes := []rune(a)
+		if len(runes) != 1 {
+			return nil, errMultiRuneAlphabet
+		}
+
+		alphabet[runes[0]] = true
+	}
+
+	return &Analyzer{v: v, profile: profile, alphabet: alphabet}, nil
+}
+
+// normalize strips any character not part of the Analyzer's alphabet, so that punctuation,
+// whitespace, or casing mistakes in a ciphertext do not skew the Kasiski, IoC, or chi-squared
+// statistics.
+func (a *Analyzer) normalize(ct string) string {
+	var b strings.Builder
+
+	for _, r := range ct {
+		if a.alphabet[r] {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// GuessKeyLength returns candidate key lengths between 2 and maxLen, ranked from most to least
+// likely, by combining Kasiski examination (the divisors of the gaps between repeated
+// trigrams/quadgrams) with Friedman's index-of-coincidence test scored against
+// `a.profile.TargetIoC`. Ciphertexts shorter than roughly 20*keyLen do not contain enough
+// repetition or enough characters per coset for either signal to be reliable; use `Confidence`
+// to check how much to trust a particular candidate before acting on it.
+func (a *Analyzer) GuessKeyLength(ct string, maxLen int) []int {
+	ct = a.normalize(ct)
+	if maxLen < 2 {
+		return nil
+	}
+
+	kasiskiCounts := a.kasiskiDivisorCounts(ct, maxLen)
+	iocDistances := a.iocDistances(ct, maxLen)
+
+	type candidate struct {
+		length int
+		score  float64
+	}
+
+	candidates := make([]candidate, 0, maxLen-1)
+	for length := 2; length <= maxLen; length++ {
+		// Kasiski divisor hits are small integer counts; IoC closeness is a distance we want
+		// to minimize, so invert it onto the same "higher is better" scale before combining.
+		score := float64(kasiskiCounts[length])
+		if distance, ok := iocDistances[length]; ok {
+			score += 1 / (1 + distance)
+		}
+
+		candidates = append(candidates, candidate{length: length, score: score})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	lengths := make([]int, len(candidates))
+	for i, c := range candidates {
+		lengths[i] = c.length
+	}
+
+	return lengths
+}
+
+// Confidence estimates, as a score in [0, 1], how reliable `GuessKeyLength` and `RecoverKey`
+// are likely to be for a ciphertext of `keyLen`. It degrades smoothly below the ~20*keyLen
+// characters needed for Kasiski repetitions and per-coset letter frequencies to stabilize,
+// rather than applying a hard cutoff.
+func (a *Analyzer) Confidence(ct string, keyLen int) float64 {
+	if keyLen <= 0 {
+		return 0
+	}
+
+	ct = a.normalize(ct)
+
+	minReliableLength := 20 * keyLen
+	if len(ct) >= minReliableLength {
+		return 1
+	}
+
+	return float64(len(ct)) / float64(minReliableLength)
+}
+
+// kasiskiDivisorCounts scans `ct` for repeated trigrams and quadgrams, and for every pair of
+// consecutive occurrences of the same n-gram, counts which divisors of the gap between them fall within
+// [2, maxLen]. A true key length divides most such gaps, so it accumulates the most hits.
+func (a *Analyzer) kasiskiDivisorCounts(ct string, maxLen int) map[int]int {
+	counts := make(map[int]int)
+	runes := []rune(ct)
+
+	for n := minKasiskiNGram; n <= maxKasiskiNGram; n++ {
+		positions := make(map[string][]int)
+		for i := 0; i+n <= len(runes); i++ {
+			gram := string(runes[i : i+n])
+			positions[gram] = append(positions[gram], i)
+		}
+
+		for _, occurrences := range positions {
+			for i := 1; i < len(occurrences); i++ {
+				gap := occurrences[i] - occurrences[i-1]
+				for d := 2; d <= maxLen; d++ {
+					if gap%d == 0 {
+						counts[d]++
+					}
+				}
+			}
+		}
+	}
+
+	return counts
+}
+
+// iocDistances computes, for every candidate length in [2, maxLen], the absolute distance
+// between the average index of coincidence of its cosets and `a.profile.TargetIoC`. Lengths
+// with too few characters per coset to compute an IoC are omitted.
+func (a *Analyzer) iocDistances(ct string, maxLen int) map[int]float64 {
+	distances := make(map[int]float64, maxLen-1)
+	runes := []rune(ct)
+
+	for length := 2; length <= maxLen; length++ {
+		cosets := make([][]rune, length)
+		for i, r := range runes {
+			cosets[i%length] = append(cosets[i%length], r)
+		}
+
+		var total float64
+		var counted int
+		for _, coset := range cosets {
+			if len(coset) < 2 {
+				continue
+			}
+
+			total += indexOfCoincidence(coset)
+			counted++
+		}
+
+		if counted == 0 {
+			continue
+		}
+
+		distances[length] = math.Abs(total/float64(counted) - a.profile.TargetIoC)
+	}
+
+	return distances
+}
+
+// indexOfCoincidence computes IoC = sum(n_i*(n_i-1)) / (N*(N-1)) for a single coset, where n_i
+// is the count of the i-th distinct character and N is the coset's length.
+func indexOfCoincidence(coset []rune) float64 {
+	counts := make(map[rune]int, len(coset))
+	for _, r := range coset {
+		counts[r]++
+	}
+
+	n := float64(len(coset))
+
+	var sum float64
+	for _, c := range counts {
+		sum += float64(c) * float64(c-1)
+	}
+
+	return sum / (n * (n - 1))
+}
+
+// RecoverKey recovers the probable `keyLen`-character key for `ct` by splitting it into
+// `keyLen` cosets (characters at positions i, i+keyLen, i+2*keyLen, ...) and, for each coset,
+// trying every shift in [0, v.Length) to find the one whose shifted letter frequencies minimize
+// the chi-squared distance to this Analyzer's `LanguageProfile.Frequencies`.
+func (a *Analyzer) RecoverKey(ct string, keyLen int) string {
+	numeric := a.v.ConvertToNumeric(a.normalize(ct))
+
+	key := make([]int64, keyLen)
+	for offset := 0; offset < keyLen; offset++ {
+		coset := make([]int64, 0, len(numeric)/keyLen+1)
+		for i := offset; i < len(numeric); i += keyLen {
+			coset = append(coset, numeric[i])
+		}
+
+		key[offset] = a.bestShift(coset)
+	}
+
+	return a.v.ConvertToString(key)
+}
+
+// bestShift returns the shift in [0, v.Length) that, when subtracted from every character in
+// `coset`, produces letter frequencies closest (by chi-squared distance) to
+// `a.profile.Frequencies`.
+func (a *Analyzer) bestShift(coset []int64) int64 {
+	var bestShift int64
+	bestDistance := math.Inf(1)
+
+	for shift := int64(0); shift < a.v.Length; shift++ {
+		counts := make([]int64, a.v.Length)
+		for _, c := range coset {
+			plain := (c - shift) % a.v.Length
+			if plain < 0 {
+				plain += a.v.Length
+			}
+
+			counts[plain]++
+		}
+
+		if distance := a.chiSquared(counts); distance < bestDistance {
+			bestDistance = distance
+			bestShift = shift
+		}
+	}
+
+	return bestShift
+}
+
+// chiSquared computes the chi-squared statistic between the observed letter `counts` (indexed
+// the same way as `v.Alphabets`) and `a.profile.Frequencies`, scaled by the total number of
+// observations.
+func (a *Analyzer) chiSquared(counts []int64) float64 {
+	var total float64
+	for _, c := range counts {
+		total += float64(c)
+	}
+
+	var sum float64
+	for i, c := range counts {
+		expected := a.profile.Frequencies[a.v.Alphabets[i]] * total
+		if expected == 0 {
+			continue
+		}
+
+		diff := float64(c) - expected
+		sum += diff * diff / expected
+	}
+
+	return sum
+}
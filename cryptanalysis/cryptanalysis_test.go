@@ -0,0 +1,127 @@
+package cryptanalysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lauslim12/vigenere"
+)
+
+// tileKey repeats key until it is at least n characters long, then truncates to exactly n,
+// the same transformation a repeating-key Vigenère cipher applies to its key.
+func tileKey(key string, n int) string {
+	var b strings.Builder
+	for b.Len() < n {
+		b.WriteString(key)
+	}
+
+	return b.String()[:n]
+}
+
+// onlyAlphabetic uppercases s and strips everything outside A-Z, producing text that
+// conforms to the default alphabet.
+func onlyAlphabetic(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// repeatingKeyFixture builds a ciphertext long enough for reliable cryptanalysis by
+// encrypting plaintext with key tiled out to the plaintext's length, exactly what a
+// repeating-key Vigenère cipher does internally.
+func repeatingKeyFixture(t *testing.T, v *vigenere.Vigenere, key string) (plaintext, ciphertext string) {
+	t.Helper()
+
+	plaintext = onlyAlphabetic(strings.Repeat(
+		"ATTACKATDAWNWECANNOTWAITANYLONGERTHEENEMYISAPPROACHINGFROMTHENORTH", 6,
+	))
+
+	secret := tileKey(key, len(plaintext))
+	ciphertext, err := v.Encrypt(plaintext, secret)
+	if err != nil {
+		t.Fatalf("Encrypt should not return an error. Got: %v.", err.Error())
+	}
+
+	return plaintext, ciphertext
+}
+
+// mustNewAnalyzer calls NewAnalyzer and fails the test if it returns an error.
+func mustNewAnalyzer(t *testing.T, v *vigenere.Vigenere, profile LanguageProfile) *Analyzer {
+	t.Helper()
+
+	analyzer, err := NewAnalyzer(v, profile)
+	if err != nil {
+		t.Fatalf("NewAnalyzer should not return an error. Got: %v.", err.Error())
+	}
+
+	return analyzer
+}
+
+func TestGuessKeyLengthFindsRepeatingKeyLength(t *testing.T) {
+	v, err := vigenere.NewVigenere(nil)
+	if err != nil {
+		t.Fatalf("NewVigenere should not return an error. Got: %v.", err.Error())
+	}
+
+	key := "LEMON"
+	_, ciphertext := repeatingKeyFixture(t, v, key)
+
+	analyzer := mustNewAnalyzer(t, v, EnglishProfile())
+	guesses := analyzer.GuessKeyLength(ciphertext, 10)
+	if len(guesses) == 0 {
+		t.Fatal("GuessKeyLength should return at least one candidate.")
+	}
+
+	if guesses[0] != len(key) {
+		t.Errorf("Expected top key length guess to be %v. Got: %v.", len(key), guesses[0])
+	}
+}
+
+func TestRecoverKeyRecoversRepeatingKey(t *testing.T) {
+	v, err := vigenere.NewVigenere(nil)
+	if err != nil {
+		t.Fatalf("NewVigenere should not return an error. Got: %v.", err.Error())
+	}
+
+	key := "LEMON"
+	_, ciphertext := repeatingKeyFixture(t, v, key)
+
+	analyzer := mustNewAnalyzer(t, v, EnglishProfile())
+	recovered := analyzer.RecoverKey(ciphertext, len(key))
+	if recovered != key {
+		t.Errorf("Expected recovered key to be %v. Got: %v.", key, recovered)
+	}
+}
+
+func TestConfidenceDegradesForShortCiphertexts(t *testing.T) {
+	v, err := vigenere.NewVigenere(nil)
+	if err != nil {
+		t.Fatalf("NewVigenere should not return an error. Got: %v.", err.Error())
+	}
+
+	analyzer := mustNewAnalyzer(t, v, EnglishProfile())
+
+	if c := analyzer.Confidence("SHORT", 5); c >= 1 {
+		t.Errorf("Confidence should be below 1 for a ciphertext shorter than 20*keyLen. Got: %v.", c)
+	}
+
+	if c := analyzer.Confidence(strings.Repeat("A", 100), 5); c != 1 {
+		t.Errorf("Confidence should be 1 once the ciphertext reaches 20*keyLen. Got: %v.", c)
+	}
+}
+
+func TestNewAnalyzerRejectsMultiRuneAlphabet(t *testing.T) {
+	v, err := vigenere.NewVigenereUnicode([]string{"AB", "CD", "EF"})
+	if err != nil {
+		t.Fatalf("NewVigenereUnicode should not return an error. Got: %v.", err.Error())
+	}
+
+	if _, err := NewAnalyzer(v, EnglishProfile()); err == nil {
+		t.Error("NewAnalyzer should reject a multi-rune alphabet.")
+	}
+}
@@ -0,0 +1,63 @@
+package vigenere
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+// errEmptyKey is an error thrown on `EncryptRepeating`/`DecryptRepeating` if `key` is empty.
+var errEmptyKey = errors.New("EncryptRepeating: key must not be empty")
+
+// EncryptOTP is an alias for Encrypt, named explicitly to distinguish the strict
+// one-time-pad mode (`secret` must be at least as long as `plaintext`) from the shorter,
+// cyclic key accepted by `EncryptRepeating`.
+func (v *Vigenere) EncryptOTP(plaintext, secret string) (string, error) {
+	return v.Encrypt(plaintext, secret)
+}
+
+// DecryptOTP is an alias for Decrypt, named explicitly to distinguish the strict
+// one-time-pad mode (`secret` must be at least as long as `ciphertext`) from the shorter,
+// cyclic key accepted by `DecryptRepeating`.
+func (v *Vigenere) DecryptOTP(ciphertext, secret string) (string, error) {
+	return v.Decrypt(ciphertext, secret)
+}
+
+// EncryptRepeating encrypts plaintext with the classical repeating-key Vigenère cipher:
+// `key` is cycled across `plaintext` (`key[i % len(key)]` supplies the secret character for
+// the i-th plaintext character), so, unlike `EncryptOTP`, `key` may be shorter than
+// `plaintext`. `key` must not be empty.
+func (v *Vigenere) EncryptRepeating(plaintext, key string) (string, error) {
+	secret, err := v.tileKey(key, plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	return v.Encrypt(plaintext, secret)
+}
+
+// DecryptRepeating decrypts ciphertext that was encrypted with `EncryptRepeating` using the
+// same `key`.
+func (v *Vigenere) DecryptRepeating(ciphertext, key string) (string, error) {
+	secret, err := v.tileKey(key, ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	return v.Decrypt(ciphertext, secret)
+}
+
+// tileKey repeats `key`'s characters until there are at least as many as there are runes in
+// `text`, producing the effective secret used by `EncryptRepeating`/`DecryptRepeating`.
+func (v *Vigenere) tileKey(key, text string) (string, error) {
+	keyRunes := []rune(key)
+	if len(keyRunes) == 0 {
+		return "", errEmptyKey
+	}
+
+	tiled := make([]rune, utf8.RuneCountInString(text))
+	for i := range tiled {
+		tiled[i] = keyRunes[i%len(keyRunes)]
+	}
+
+	return string(tiled), nil
+}
@@ -0,0 +1,134 @@
+package vigenere
+
+import (
+	"log"
+	"testing"
+)
+
+func TestEncryptRepeating(t *testing.T) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	tests := []struct {
+		name           string
+		plaintext      string
+		key            string
+		expectedOutput string
+		expectedError  bool
+	}{
+		{
+			name:           "test_success_encrypt_repeating",
+			plaintext:      "ATTACKATDAWN",
+			key:            "LEMON",
+			expectedOutput: "LXFOPVEFRNHR",
+			expectedError:  false,
+		},
+		{
+			name:           "test_failure_encrypt_repeating_empty_key",
+			plaintext:      "ATTACKATDAWN",
+			key:            "",
+			expectedOutput: "",
+			expectedError:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := vigenere.EncryptRepeating(tc.plaintext, tc.key)
+			if err != nil && !tc.expectedError {
+				t.Errorf("EncryptRepeating method should not result in an error. Got: %v.", err.Error())
+			}
+
+			if tc.expectedOutput != result {
+				t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", tc.expectedOutput, result)
+			}
+		})
+	}
+}
+
+func TestDecryptRepeating(t *testing.T) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	tests := []struct {
+		name           string
+		ciphertext     string
+		key            string
+		expectedOutput string
+		expectedError  bool
+	}{
+		{
+			name:           "test_success_decrypt_repeating",
+			ciphertext:     "LXFOPVEFRNHR",
+			key:            "LEMON",
+			expectedOutput: "ATTACKATDAWN",
+			expectedError:  false,
+		},
+		{
+			name:           "test_failure_decrypt_repeating_empty_key",
+			ciphertext:     "LXFOPVEFRNHR",
+			key:            "",
+			expectedOutput: "",
+			expectedError:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := vigenere.DecryptRepeating(tc.ciphertext, tc.key)
+			if err != nil && !tc.expectedError {
+				t.Errorf("DecryptRepeating method should not result in an error. Got: %v.", err.Error())
+			}
+
+			if tc.expectedOutput != result {
+				t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", tc.expectedOutput, result)
+			}
+		})
+	}
+}
+
+func TestEncryptDecryptRepeatingRoundTrip(t *testing.T) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	ciphertext, err := vigenere.EncryptRepeating("ATTACKATDAWN", "LEMON")
+	if err != nil {
+		t.Fatalf("EncryptRepeating should not return an error. Got: %v.", err.Error())
+	}
+
+	plaintext, err := vigenere.DecryptRepeating(ciphertext, "LEMON")
+	if err != nil {
+		t.Fatalf("DecryptRepeating should not return an error. Got: %v.", err.Error())
+	}
+
+	if plaintext != "ATTACKATDAWN" {
+		t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", "ATTACKATDAWN", plaintext)
+	}
+}
+
+func TestEncryptOTPDecryptOTPAliases(t *testing.T) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	ciphertext, err := vigenere.EncryptOTP("HELLO", "WORLD")
+	if err != nil {
+		t.Fatalf("EncryptOTP should not return an error. Got: %v.", err.Error())
+	}
+
+	plaintext, err := vigenere.DecryptOTP(ciphertext, "WORLD")
+	if err != nil {
+		t.Fatalf("DecryptOTP should not return an error. Got: %v.", err.Error())
+	}
+
+	if plaintext != "HELLO" {
+		t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", "HELLO", plaintext)
+	}
+}
@@ -0,0 +1,251 @@
+package vigenere
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// aeadNonceSize is the size, in bytes, of the nonce accepted by `Seal`/`Open`.
+const aeadNonceSize = 24
+
+// aeadTagSize is the size, in bytes, of the authentication tag appended by `Seal`
+// and verified by `Open`.
+const aeadTagSize = 16
+
+// errAEADMasterKeyEmpty is an error thrown on `NewVigenereAEAD` if `masterKey` is empty.
+var errAEADMasterKeyEmpty = errors.New("NewVigenereAEAD: master key must not be empty")
+
+// aeadEncInfo and aeadMacInfo are the HKDF "info" labels `NewVigenereAEAD` uses to derive
+// `encKey` and `macKey` from a single master key, so that `deriveSecret`'s HKDF expansion and
+// `tag`'s HMAC key off two independent subkeys instead of the same raw secret.
+var (
+	aeadEncInfo = []byte("lauslim12/vigenere AEAD encryption key v1")
+	aeadMacInfo = []byte("lauslim12/vigenere AEAD authentication key v1")
+)
+
+// ErrUnauthenticated is returned by `Open` when the authentication tag (or, equivalently,
+// the nonce or additional data) does not match, meaning the ciphertext has been tampered
+// with, the wrong key/nonce was used, or the additional data does not match what was sealed.
+var ErrUnauthenticated = errors.New("Open: ciphertext failed authentication")
+
+// NewVigenereAEAD creates a new `Vigenere` instance configured with a master key, enabling
+// the `Seal`/`Open` methods. Unlike the plain `Encrypt`/`Decrypt` pair, which require a secret
+// as long as the plaintext, the master key here is reused across many messages: per-message
+// security instead comes from combining it with a unique `nonce` on every call to `Seal`, the
+// same contract as `crypto/cipher.AEAD`. `masterKey` must not be empty. `masterKey` itself is
+// never stored or used directly: it is immediately expanded via HKDF-SHA256 into an `encKey`
+// and a `macKey`, so that a weakness in one derivation can't be leveraged against the other.
+func NewVigenereAEAD(alphabets []string, masterKey []byte) (*Vigenere, error) {
+	if len(masterKey) == 0 {
+		return nil, errAEADMasterKeyEmpty
+	}
+
+	v, err := NewVigenere(alphabets)
+	if err != nil {
+		return nil, err
+	}
+
+	encKey := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, masterKey, nil, aeadEncInfo), encKey); err != nil {
+		return nil, err
+	}
+
+	macKey := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, masterKey, nil, aeadMacInfo), macKey); err != nil {
+		return nil, err
+	}
+
+	v.encKey = encKey
+	v.macKey = macKey
+	return v, nil
+}
+
+// NonceSize returns the size, in bytes, of the nonce that must be passed to `Seal` and `Open`.
+func (v *Vigenere) NonceSize() int {
+	return aeadNonceSize
+}
+
+// Overhead returns the number of bytes of overhead added by `Seal`, i.e. the size of the
+// authentication tag appended after the ciphertext.
+func (v *Vigenere) Overhead() int {
+	return aeadTagSize
+}
+
+// deriveSecret expands `v.encKey`, nonce, and additional data into `n` secret characters via
+// HKDF-SHA256, used as a one-time-pad-equivalent secret for that single message. Reusing a
+// nonce with the same master key and additional data therefore reuses the same secret,
+// exactly as reusing a nonce breaks any `crypto/cipher.AEAD`.
+func (v *Vigenere) deriveSecret(nonce, additionalData []byte, n int) (string, error) {
+	// One HKDF-SHA256 expansion yields at most 255*32 bytes, so a fresh expansion is started
+	// every drawsPerBlock draws, with additionalData followed by the 8-byte big-endian block
+	// number as its info. 512 draws use 4096 bytes, leaving the rest for redraws.
+	const drawsPerBlock = 512
+
+	// limit is the largest multiple of v.Length that still fits in a uint64. Rejecting and
+	// redrawing any value at or above it removes the bias a plain `% v.Length` would
+	// otherwise introduce (since 2^64 is not, in general, a multiple of v.Length), the same
+	// rejection sampling `GenerateRandomNumber` gets for free from `crypto/rand.Int`.
+	limit := ^uint64(0) - ^uint64(0)%uint64(v.Length)
+
+	secret := make([]int64, n)
+	buf := make([]byte, 8)
+	var kdf io.Reader
+	for i := 0; i < n; i++ {
+		if i%drawsPerBlock == 0 {
+			info := binary.BigEndian.AppendUint64(append([]byte(nil), additionalData...), uint64(i/drawsPerBlock))
+			kdf = hkdf.New(sha256.New, v.encKey, nonce, info)
+		}
+
+		draw, err := drawBelow(kdf, buf, limit)
+		if err != nil {
+			return "", err
+		}
+
+		secret[i] = int64(draw % uint64(v.Length))
+	}
+
+	return v.ConvertToString(secret), nil
+}
+
+// drawBelow reads 8-byte big-endian values from kdf into buf until one falls below limit,
+// discarding any draw at or above it, and returns that value.
+func drawBelow(kdf io.Reader, buf []byte, limit uint64) (uint64, error) {
+	for {
+		if _, err := io.ReadFull(kdf, buf); err != nil {
+			return 0, err
+		}
+
+		if draw := binary.BigEndian.Uint64(buf); draw < limit {
+			return draw, nil
+		}
+	}
+}
+
+// tag computes the authentication tag over nonce||additionalData||ciphertext, truncated
+// to `aeadTagSize` bytes. Each field is length-prefixed so that, say, an empty `additionalData`
+// cannot be confused with a shifted split between `nonce` and `ciphertext`.
+func (v *Vigenere) tag(nonce, additionalData, ciphertext []byte) []byte {
+	mac := hmac.New(sha256.New, v.macKey)
+	writeLenPrefixed(mac, nonce)
+	writeLenPrefixed(mac, additionalData)
+	writeLenPrefixed(mac, ciphertext)
+
+	return mac.Sum(nil)[:aeadTagSize]
+}
+
+// writeLenPrefixed writes an 8-byte big-endian length prefix followed by `b` to `w`.
+func writeLenPrefixed(w io.Writer, b []byte) {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(b)))
+	w.Write(length[:])
+	w.Write(b)
+}
+
+// Seal derives a per-message secret from the master key, `nonce`, and `additionalData`,
+// uses it to drive the Vigenère shifts over `plaintext` (the same algorithm as `Encrypt`),
+// appends a truncated HMAC-SHA256 tag over the nonce, additional data, and ciphertext, and
+// returns the result appended to `dst`. `nonce` must be `NonceSize()` bytes and must never
+// be reused with the same master key and additional data. `additionalData` is authenticated
+// but not encrypted, and must be passed unchanged to `Open`. Seal panics if `v` was not
+// created with `NewVigenereAEAD`, if `nonce` is the wrong length, or if `plaintext` does not
+// conform to `v`'s `Alphabets` (check with `ValidateString` first if `plaintext` isn't known
+// to conform), mirroring `crypto/cipher.AEAD`'s contract that `Seal` never returns an error.
+func (v *Vigenere) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(v.encKey) == 0 {
+		panic("Seal: Vigenere instance has no master key, use NewVigenereAEAD")
+	}
+
+	if len(nonce) != v.NonceSize() {
+		panic("Seal: incorrect nonce length given to Vigenere AEAD")
+	}
+
+	pt := string(plaintext)
+	if ok, err := v.ValidateString(pt); !ok {
+		panic(err)
+	}
+
+	secret, err := v.deriveSecret(nonce, additionalData, utf8.RuneCountInString(pt))
+	if err != nil {
+		panic(err)
+	}
+
+	ciphertext, err := v.Encrypt(pt, secret)
+	if err != nil {
+		panic(err)
+	}
+
+	ciphertextBytes := []byte(ciphertext)
+	tag := v.tag(nonce, additionalData, ciphertextBytes)
+
+	ret, out := sliceForAppend(dst, len(ciphertextBytes)+len(tag))
+	n := copy(out, ciphertextBytes)
+	copy(out[n:], tag)
+
+	return ret
+}
+
+// Open verifies the authentication tag appended to `ciphertext` and, if it matches,
+// reverses `Seal` to recover the plaintext, appending it to `dst`. `nonce` and
+// `additionalData` must be exactly what was passed to the matching `Seal` call. Open
+// returns `ErrUnauthenticated` if the tag does not match, without revealing a decrypted
+// plaintext for a tampered ciphertext, nonce, or additional data. Open panics if `v` was
+// not created with `NewVigenereAEAD` or if `nonce` is the wrong length.
+func (v *Vigenere) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(v.encKey) == 0 {
+		panic("Open: Vigenere instance has no master key, use NewVigenereAEAD")
+	}
+
+	if len(nonce) != v.NonceSize() {
+		panic("Open: incorrect nonce length given to Vigenere AEAD")
+	}
+
+	if len(ciphertext) < v.Overhead() {
+		return nil, ErrUnauthenticated
+	}
+
+	body := ciphertext[:len(ciphertext)-v.Overhead()]
+	gotTag := ciphertext[len(ciphertext)-v.Overhead():]
+	wantTag := v.tag(nonce, additionalData, body)
+
+	if subtle.ConstantTimeCompare(gotTag, wantTag) != 1 {
+		return nil, ErrUnauthenticated
+	}
+
+	ct := string(body)
+	secret, err := v.deriveSecret(nonce, additionalData, utf8.RuneCountInString(ct))
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := v.Decrypt(ct, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, out := sliceForAppend(dst, len(plaintext))
+	copy(out, plaintext)
+
+	return ret, nil
+}
+
+// sliceForAppend extends `in` by `n` bytes, reusing its backing array when it has enough
+// spare capacity, and returns the extended slice along with the newly appended region.
+// Lifted from the same helper in the standard library's crypto/cipher GCM implementation.
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+
+	tail = head[len(in):]
+	return
+}
@@ -0,0 +1,107 @@
+package vigenere
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+)
+
+func newAEADFixture(t *testing.T) (*Vigenere, []byte) {
+	t.Helper()
+
+	v, err := NewVigenereAEAD(nil, []byte("a long-lived master key, reused across messages"))
+	if err != nil {
+		t.Fatalf("NewVigenereAEAD should not return an error. Got: %v.", err.Error())
+	}
+
+	nonce := make([]byte, v.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		t.Fatalf("generating a nonce should not fail. Got: %v.", err.Error())
+	}
+
+	return v, nonce
+}
+
+// mustSeal calls Seal with in-alphabet plaintext and fails the test if it panics.
+func mustSeal(t *testing.T, v *Vigenere, nonce, plaintext, additionalData []byte) []byte {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Seal should not panic for in-alphabet plaintext. Got: %v.", r)
+		}
+	}()
+
+	return v.Seal(nil, nonce, plaintext, additionalData)
+}
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	v, nonce := newAEADFixture(t)
+
+	plaintext := []byte("CITYOFJAKARTA")
+	ad := []byte("message-id:1")
+
+	sealed := mustSeal(t, v, nonce, plaintext, ad)
+
+	opened, err := v.Open(nil, nonce, sealed, ad)
+	if err != nil {
+		t.Fatalf("Open should not return an error for an untampered ciphertext. Got: %v.", err.Error())
+	}
+
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", string(plaintext), string(opened))
+	}
+}
+
+func TestOpenRejectsTamperedCiphertext(t *testing.T) {
+	v, nonce := newAEADFixture(t)
+
+	sealed := mustSeal(t, v, nonce, []byte("CITYOFJAKARTA"), []byte("message-id:1"))
+	sealed[0] ^= 0xFF
+
+	if _, err := v.Open(nil, nonce, sealed, []byte("message-id:1")); err != ErrUnauthenticated {
+		t.Errorf("Open should return ErrUnauthenticated for a tampered ciphertext. Got: %v.", err)
+	}
+}
+
+func TestOpenRejectsTamperedNonce(t *testing.T) {
+	v, nonce := newAEADFixture(t)
+
+	sealed := mustSeal(t, v, nonce, []byte("CITYOFJAKARTA"), []byte("message-id:1"))
+
+	tamperedNonce := append([]byte(nil), nonce...)
+	tamperedNonce[0] ^= 0xFF
+
+	if _, err := v.Open(nil, tamperedNonce, sealed, []byte("message-id:1")); err != ErrUnauthenticated {
+		t.Errorf("Open should return ErrUnauthenticated for a tampered nonce. Got: %v.", err)
+	}
+}
+
+func TestOpenRejectsTamperedAdditionalData(t *testing.T) {
+	v, nonce := newAEADFixture(t)
+
+	sealed := mustSeal(t, v, nonce, []byte("CITYOFJAKARTA"), []byte("message-id:1"))
+
+	if _, err := v.Open(nil, nonce, sealed, []byte("message-id:2")); err != ErrUnauthenticated {
+		t.Errorf("Open should return ErrUnauthenticated for mismatched additional data. Got: %v.", err)
+	}
+}
+
+func TestNewVigenereAEADRejectsEmptyKey(t *testing.T) {
+	if _, err := NewVigenereAEAD(nil, nil); err != errAEADMasterKeyEmpty {
+		t.Errorf("NewVigenereAEAD should reject an empty master key. Got: %v.", err)
+	}
+}
+
+func TestSealPanicsOnOutOfAlphabetPlaintext(t *testing.T) {
+	v, nonce := newAEADFixture(t)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Seal should panic for plaintext outside the alphabet instead of silently dropping it.")
+		}
+	}()
+
+	v.Seal(nil, nonce, []byte("Hello, World!"), nil)
+}
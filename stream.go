@@ -0,0 +1,208 @@
+package vigenere
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// streamChunkSize is the number of bytes processed per chunk by the streaming
+// Encrypt/Decrypt helpers below, mirroring the chunk size used by rclone's crypt
+// backend. Processing in fixed-size chunks means the plaintext, ciphertext, and
+// secret never need to be fully materialized in memory.
+const streamChunkSize = 64 * 1024
+
+// errStreamNilReaderWriter is an error thrown on `NewEncryptStream` if `dst` or
+// `secret` is nil.
+var errStreamNilReaderWriter = errors.New("NewEncryptStream: dst and secret must not be nil")
+
+// streamChunkCutoff returns how many of buf's leading bytes can be processed as one
+// streaming chunk: at most `streamChunkSize`, and never splitting a multi-byte UTF-8
+// rune across the boundary (which would otherwise corrupt whichever alphabet entry or
+// pass-through character straddled the cut). If `final` is true, the whole buffer is
+// returned, since there is no further input to complete a split rune with.
+func (v *Vigenere) streamChunkCutoff(buf []byte, final bool) int {
+	if final {
+		return len(buf)
+	}
+
+	if len(buf) < streamChunkSize {
+		return 0
+	}
+
+	limit := streamChunkSize
+	for limit > 0 && limit < len(buf) && !utf8.RuneStart(buf[limit]) {
+		limit--
+	}
+
+	return limit
+}
+
+// encryptStream is the io.WriteCloser returned by `NewEncryptStream`. Plaintext written
+// to it is buffered until a full, rune-boundary-safe chunk of at most `streamChunkSize`
+// bytes is available; for each such chunk, an equal amount of key material is pulled
+// from `secret` and the resulting ciphertext is written to `dst` immediately.
+type encryptStream struct {
+	v       *Vigenere
+	dst     io.Writer
+	secret  io.Reader
+	keyBuf  []byte
+	pending []byte
+}
+
+// NewEncryptStream returns an io.WriteCloser that encrypts data written to it in
+// fixed-size chunks, writing the ciphertext to `dst` as it goes. Key material is
+// pulled lazily from `secret`, one chunk's worth at a time, so both the plaintext
+// and the secret can be arbitrarily large without being held fully in memory. This
+// is the streaming counterpart to `Encrypt`, meant for encrypting files or network
+// streams. Callers must call `Close` to flush any plaintext still buffered below a
+// full chunk.
+func (v *Vigenere) NewEncryptStream(dst io.Writer, secret io.Reader) (io.WriteCloser, error) {
+	if dst == nil || secret == nil {
+		return nil, errStreamNilReaderWriter
+	}
+
+	return &encryptStream{
+		v:      v,
+		dst:    dst,
+		secret: secret,
+		keyBuf: make([]byte, streamChunkSize),
+	}, nil
+}
+
+// Write buffers `p` and encrypts as many full, rune-boundary-safe chunks as are
+// available, writing their ciphertext to the underlying `dst`. Any trailing bytes too
+// short to form a full chunk are held until the next `Write` or `Close`.
+func (s *encryptStream) Write(p []byte) (int, error) {
+	s.pending = append(s.pending, p...)
+
+	for {
+		n := s.v.streamChunkCutoff(s.pending, false)
+		if n == 0 {
+			break
+		}
+
+		if err := s.encryptChunk(s.pending[:n]); err != nil {
+			return 0, err
+		}
+
+		s.pending = s.pending[n:]
+	}
+
+	return len(p), nil
+}
+
+// encryptChunk pulls enough key material to cover `chunk`, encrypts it, and writes the
+// ciphertext to `dst`.
+func (s *encryptStream) encryptChunk(chunk []byte) error {
+	key := s.keyBuf[:len(chunk)]
+	if _, err := io.ReadFull(s.secret, key); err != nil {
+		return err
+	}
+
+	ciphertext, err := s.v.Encrypt(string(chunk), string(key))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(s.dst, ciphertext)
+	return err
+}
+
+// Close flushes any plaintext still buffered below a full chunk.
+func (s *encryptStream) Close() error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+
+	err := s.encryptChunk(s.pending)
+	s.pending = nil
+
+	return err
+}
+
+// decryptStream is the io.Reader returned by `NewDecryptStream`. It pulls ciphertext
+// from `src`, buffers it until a full, rune-boundary-safe chunk of at most
+// `streamChunkSize` bytes is available, and decrypts each such chunk using an equal
+// amount of key material pulled from `secret`, serving the resulting plaintext to
+// callers of `Read`.
+type decryptStream struct {
+	v          *Vigenere
+	src        io.Reader
+	secret     io.Reader
+	readBuf    []byte
+	keyBuf     []byte
+	ciphertext []byte       // buffered ciphertext not yet decrypted
+	plaintext  bytes.Buffer // decrypted plaintext not yet returned to the caller
+	err        error        // sticky error from src, secret, or Decrypt; returned once plaintext drains
+}
+
+// NewDecryptStream returns an io.Reader that decrypts ciphertext pulled from `src`
+// in fixed-size chunks, pulling an equal amount of key material from `secret` for
+// each chunk. This is the streaming counterpart to `Decrypt`, meant for decrypting
+// files or network streams without materializing the whole ciphertext and secret.
+func (v *Vigenere) NewDecryptStream(src io.Reader, secret io.Reader) io.Reader {
+	return &decryptStream{
+		v:       v,
+		src:     src,
+		secret:  secret,
+		readBuf: make([]byte, streamChunkSize),
+		keyBuf:  make([]byte, streamChunkSize),
+	}
+}
+
+// Read fills `p` with decrypted plaintext, pulling and decrypting further chunks
+// from `src` as needed. Once `src` or `secret` returns an error (including io.EOF),
+// Read keeps serving any plaintext already decrypted before finally returning that
+// error, so data read alongside a terminal error is never lost.
+func (s *decryptStream) Read(p []byte) (int, error) {
+	for s.plaintext.Len() == 0 && s.err == nil {
+		n, err := s.src.Read(s.readBuf)
+		if n > 0 {
+			s.ciphertext = append(s.ciphertext, s.readBuf[:n]...)
+		}
+
+		if err != nil {
+			s.err = err
+		}
+
+		final := s.err != nil
+		for {
+			cut := s.v.streamChunkCutoff(s.ciphertext, final)
+			if cut == 0 {
+				break
+			}
+
+			if derr := s.decryptChunk(s.ciphertext[:cut]); derr != nil {
+				s.err = derr
+				break
+			}
+
+			s.ciphertext = s.ciphertext[cut:]
+		}
+	}
+
+	if s.plaintext.Len() > 0 {
+		return s.plaintext.Read(p)
+	}
+
+	return 0, s.err
+}
+
+// decryptChunk pulls enough key material to cover `chunk`, decrypts it, and appends the
+// plaintext to `s.plaintext`.
+func (s *decryptStream) decryptChunk(chunk []byte) error {
+	key := s.keyBuf[:len(chunk)]
+	if _, err := io.ReadFull(s.secret, key); err != nil {
+		return err
+	}
+
+	plaintext, err := s.v.Decrypt(string(chunk), string(key))
+	if err != nil {
+		return err
+	}
+
+	s.plaintext.WriteString(plaintext)
+	return nil
+}
@@ -0,0 +1,193 @@
+package vigenere
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"testing"
+)
+
+// newStreamFixture builds a plaintext/secret pair of `size` bytes over the default
+// alphabets, suitable for both the plain and streaming Encrypt/Decrypt benchmarks.
+func newStreamFixture(t testing.TB, v *Vigenere, size int) (string, string) {
+	t.Helper()
+
+	plaintext := make([]byte, size)
+	for i := range plaintext {
+		plaintext[i] = v.Alphabets[i%int(v.Length)][0]
+	}
+
+	secret, err := v.GenerateSecretKey(string(plaintext))
+	if err != nil {
+		t.Fatalf("GenerateSecretKey should not return an error. Got: %v.", err.Error())
+	}
+
+	return string(plaintext), secret
+}
+
+func TestEncryptDecryptStream(t *testing.T) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	plaintext, secret := newStreamFixture(t, vigenere, streamChunkSize*3+17)
+
+	var ciphertext bytes.Buffer
+	encStream, err := vigenere.NewEncryptStream(&ciphertext, bytes.NewBufferString(secret))
+	if err != nil {
+		t.Fatalf("NewEncryptStream should not return an error. Got: %v.", err.Error())
+	}
+
+	if _, err := io.Copy(encStream, bytes.NewBufferString(plaintext)); err != nil {
+		t.Fatalf("Writing to the encrypt stream should not fail. Got: %v.", err.Error())
+	}
+
+	if err := encStream.Close(); err != nil {
+		t.Fatalf("Closing the encrypt stream should not fail. Got: %v.", err.Error())
+	}
+
+	decStream := vigenere.NewDecryptStream(&ciphertext, bytes.NewBufferString(secret))
+	decrypted, err := io.ReadAll(decStream)
+	if err != nil {
+		t.Fatalf("Reading from the decrypt stream should not fail. Got: %v.", err.Error())
+	}
+
+	if string(decrypted) != plaintext {
+		t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", plaintext, string(decrypted))
+	}
+}
+
+func TestEncryptDecryptStreamDoesNotSplitMultiByteRunes(t *testing.T) {
+	// Build a Unicode alphabet whose entries are multi-byte runes, and size the plaintext
+	// so that a naive byte-count chunk boundary would land in the middle of one of them.
+	v, err := NewVigenereUnicode([]string{"あ", "い", "う"})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	runes := []string{"あ", "い", "う"}
+	var plaintext bytes.Buffer
+	for i := 0; plaintext.Len() < streamChunkSize+4; i++ {
+		plaintext.WriteString(runes[i%len(runes)])
+	}
+
+	secret, err := v.GenerateSecretKey(plaintext.String())
+	if err != nil {
+		t.Fatalf("GenerateSecretKey should not return an error. Got: %v.", err.Error())
+	}
+
+	var ciphertext bytes.Buffer
+	encStream, err := v.NewEncryptStream(&ciphertext, bytes.NewBufferString(secret))
+	if err != nil {
+		t.Fatalf("NewEncryptStream should not return an error. Got: %v.", err.Error())
+	}
+
+	if _, err := io.Copy(encStream, bytes.NewReader(plaintext.Bytes())); err != nil {
+		t.Fatalf("Writing to the encrypt stream should not fail. Got: %v.", err.Error())
+	}
+
+	if err := encStream.Close(); err != nil {
+		t.Fatalf("Closing the encrypt stream should not fail. Got: %v.", err.Error())
+	}
+
+	decStream := v.NewDecryptStream(&ciphertext, bytes.NewBufferString(secret))
+	decrypted, err := io.ReadAll(decStream)
+	if err != nil {
+		t.Fatalf("Reading from the decrypt stream should not fail. Got: %v.", err.Error())
+	}
+
+	if string(decrypted) != plaintext.String() {
+		t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", plaintext.String(), string(decrypted))
+	}
+}
+
+// dataThenErrReader returns data and a non-EOF error from the same Read call, exercising
+// the case where a decrypt stream must not discard plaintext already decrypted from it.
+type dataThenErrReader struct {
+	data []byte
+	err  error
+	sent bool
+}
+
+func (r *dataThenErrReader) Read(p []byte) (int, error) {
+	if r.sent {
+		return 0, r.err
+	}
+
+	r.sent = true
+	n := copy(p, r.data)
+	return n, r.err
+}
+
+func TestDecryptStreamKeepsDataReturnedAlongsideError(t *testing.T) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	plaintext, secret := newStreamFixture(t, vigenere, 32)
+
+	var ciphertext bytes.Buffer
+	encStream, err := vigenere.NewEncryptStream(&ciphertext, bytes.NewBufferString(secret))
+	if err != nil {
+		t.Fatalf("NewEncryptStream should not return an error. Got: %v.", err.Error())
+	}
+
+	if _, err := io.Copy(encStream, bytes.NewBufferString(plaintext)); err != nil {
+		t.Fatalf("Writing to the encrypt stream should not fail. Got: %v.", err.Error())
+	}
+
+	if err := encStream.Close(); err != nil {
+		t.Fatalf("Closing the encrypt stream should not fail. Got: %v.", err.Error())
+	}
+
+	src := &dataThenErrReader{data: ciphertext.Bytes(), err: io.ErrUnexpectedEOF}
+	decStream := vigenere.NewDecryptStream(src, bytes.NewBufferString(secret))
+
+	decrypted, err := io.ReadAll(decStream)
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected io.ReadAll to surface the underlying reader error. Got: %v.", err)
+	}
+
+	if string(decrypted) != plaintext {
+		t.Errorf("Plaintext decrypted alongside the error should still be returned. Expected: %v. Got: %v.", plaintext, string(decrypted))
+	}
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	plaintext, secret := newStreamFixture(b, vigenere, 4*1024*1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := vigenere.Encrypt(plaintext, secret); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncryptStream(b *testing.B) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	plaintext, secret := newStreamFixture(b, vigenere, 4*1024*1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encStream, err := vigenere.NewEncryptStream(io.Discard, bytes.NewBufferString(secret))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if _, err := io.Copy(encStream, bytes.NewBufferString(plaintext)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package vigenere
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// PreserveMode controls how Encrypt, Decrypt, ValidateString, and GenerateSecretKey treat
+// characters that are not part of a Vigenere's Alphabets.
+type PreserveMode int
+
+const (
+	// Strict is the default: ValidateString rejects any character outside Alphabets, and
+	// Encrypt/Decrypt silently drop such characters instead of encrypting them.
+	Strict PreserveMode = iota
+
+	// PassThrough copies characters outside Alphabets verbatim to the output, and does not
+	// consume a character of secret for them.
+	PassThrough
+
+	// CasePreserve treats Alphabets as case-folded (so both "a" and "A" match an "A" entry),
+	// but restores each output character to the casing of the corresponding input character.
+	// Like PassThrough, characters outside Alphabets (even case-folded) are copied through
+	// verbatim rather than dropped, and do not consume a character of secret.
+	CasePreserve
+)
+
+// errUnicodeAlphabetRequired is an error thrown on `NewVigenereUnicode` if `alphabet` is empty.
+// Unlike `NewVigenere`, there is no sensible default alphabet for grapheme-cluster alphabets.
+var errUnicodeAlphabetRequired = errors.New("NewVigenereUnicode: alphabet must not be empty")
+
+// NewVigenereUnicode creates a new `Vigenere` instance whose alphabet entries may be
+// arbitrary grapheme clusters (emoji, combining marks, kana with dakuten, and so on) rather
+// than single runes. Both `alphabet` and any text later passed to `Encrypt`, `Decrypt`,
+// `ValidateString`, or `GenerateSecretKey` are NFC-normalized before indexing, so visually
+// identical but differently-composed Unicode sequences are treated as equal. As with
+// `NewVigenere`, `alphabet` must not contain duplicates.
+func NewVigenereUnicode(alphabet []string) (*Vigenere, error) {
+	if len(alphabet) == 0 {
+		return nil, errUnicodeAlphabetRequired
+	}
+
+	set := make(map[string]bool, len(alphabet))
+	graphemeIndex := make(map[string]int64, len(alphabet))
+	normalized := make([]string, len(alphabet))
+	maxGraphemeLen := 1
+
+	for i, a := range alphabet {
+		a = norm.NFC.String(a)
+
+		if set[a] {
+			return nil, errDuplicateAlphabets
+		}
+
+		set[a] = true
+		normalized[i] = a
+		graphemeIndex[a] = int64(i)
+
+		if n := utf8.RuneCountInString(a); n > maxGraphemeLen {
+			maxGraphemeLen = n
+		}
+	}
+
+	return &Vigenere{
+		Alphabets:      normalized,
+		Length:         int64(len(normalized)),
+		RandomSource:   rand.Reader,
+		unicode:        true,
+		graphemeIndex:  graphemeIndex,
+		maxGraphemeLen: maxGraphemeLen,
+	}, nil
+}
+
+// symbol is one token produced by `tokenize`: either a match against an alphabet entry, or
+// an unmatched literal character.
+type symbol struct {
+	text    string // the original substring consumed from the input
+	index   int64  // alphabet index; only meaningful when matched is true
+	matched bool
+}
+
+// tokenize splits str into a sequence of symbols. For grapheme-cluster alphabets (built via
+// `NewVigenereUnicode`), it greedily matches the longest alphabet entry starting at each
+// position, since a single rune is not necessarily a full grapheme cluster. Characters with
+// no match become unmatched literal symbols, handled by the caller according to `PreserveMode`.
+func (v *Vigenere) tokenize(str string) []symbol {
+	if v.unicode {
+		str = norm.NFC.String(str)
+	}
+
+	runes := []rune(str)
+	symbols := make([]symbol, 0, len(runes))
+
+	maxWindow := v.maxGraphemeLen
+	if maxWindow < 1 {
+		maxWindow = 1
+	}
+
+	for i := 0; i < len(runes); {
+		window := maxWindow
+		if i+window > len(runes) {
+			window = len(runes) - i
+		}
+
+		matched := false
+		for w := window; w >= 1; w-- {
+			candidate := string(runes[i : i+w])
+			if idx, ok := v.lookup(candidate); ok {
+				symbols = append(symbols, symbol{text: candidate, index: idx, matched: true})
+				i += w
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			symbols = append(symbols, symbol{text: string(runes[i])})
+			i++
+		}
+	}
+
+	return symbols
+}
+
+// lookup resolves candidate to its alphabet index, trying an exact match first and, under
+// `CasePreserve`, an upper-cased match second.
+func (v *Vigenere) lookup(candidate string) (int64, bool) {
+	if idx, ok := v.lookupExact(candidate); ok {
+		return idx, true
+	}
+
+	if v.PreserveMode == CasePreserve {
+		return v.lookupExact(strings.ToUpper(candidate))
+	}
+
+	return 0, false
+}
+
+// lookupExact resolves candidate to its alphabet index with no case folding, via whichever
+// index (`alphabetIndex` or `graphemeIndex`) matches this Vigenere's construction.
+func (v *Vigenere) lookupExact(candidate string) (int64, bool) {
+	if v.unicode || v.multiRune {
+		idx, ok := v.graphemeIndex[candidate]
+		return idx, ok
+	}
+
+	if utf8.RuneCountInString(candidate) != 1 {
+		return 0, false
+	}
+
+	idx, ok := v.alphabetIndex[[]rune(candidate)[0]]
+	return idx, ok
+}
+
+// isLower reports whether candidate's first rune is lower case, used by `CasePreserve` to
+// decide whether to restore an output character to lower case.
+func isLower(candidate string) bool {
+	for _, r := range candidate {
+		return unicode.IsLower(r)
+	}
+
+	return false
+}
+
+// restoreCase lower-cases alphabet if original was lower case and v is in CasePreserve mode,
+// leaving alphabet untouched otherwise.
+func (v *Vigenere) restoreCase(alphabet, original string) string {
+	if v.PreserveMode == CasePreserve && isLower(original) {
+		return strings.ToLower(alphabet)
+	}
+
+	return alphabet
+}
+
+// secretNumeric converts secret to its numeric equivalent via `tokenize`, rather than
+// `ConvertToNumeric`'s single-rune fast path, so that a `CasePreserve` secret is case-folded
+// the same way plaintext/ciphertext is instead of silently matching nothing.
+func (v *Vigenere) secretNumeric(secret string) []int64 {
+	numeric := make([]int64, 0, utf8.RuneCountInString(secret))
+	for _, sym := range v.tokenize(secret) {
+		if sym.matched {
+			numeric = append(numeric, sym.index)
+		}
+	}
+
+	return numeric
+}
+
+// encryptGeneral is the `Encrypt` path used whenever `v` is in unicode mode or a non-Strict
+// `PreserveMode`, since those need to track unmatched characters and letter case alongside
+// the numeric conversion that the fast path in `Encrypt` doesn't.
+func (v *Vigenere) encryptGeneral(plaintext, secret string) (string, error) {
+	symbols := v.tokenize(plaintext)
+	if utf8.RuneCountInString(secret) < v.keyLength(plaintext) {
+		return "", errEncryptLengthNotEqual
+	}
+
+	numericSecret := v.secretNumeric(secret)
+
+	var out strings.Builder
+	keyPos := 0
+
+	for _, sym := range symbols {
+		if !sym.matched {
+			if v.PreserveMode == PassThrough || v.PreserveMode == CasePreserve {
+				out.WriteString(sym.text)
+			}
+
+			continue
+		}
+
+		shifted := (numericSecret[keyPos] + sym.index) % v.Length
+		keyPos++
+
+		out.WriteString(v.restoreCase(v.Alphabets[shifted], sym.text))
+	}
+
+	return out.String(), nil
+}
+
+// decryptGeneral is the `Decrypt` path used whenever `v` is in unicode mode or a non-Strict
+// `PreserveMode`, mirroring `encryptGeneral`.
+func (v *Vigenere) decryptGeneral(ciphertext, secret string) (string, error) {
+	symbols := v.tokenize(ciphertext)
+	if utf8.RuneCountInString(secret) < v.keyLength(ciphertext) {
+		return "", errDecryptLengthNotEqual
+	}
+
+	numericSecret := v.secretNumeric(secret)
+
+	var out strings.Builder
+	keyPos := 0
+
+	for _, sym := range symbols {
+		if !sym.matched {
+			if v.PreserveMode == PassThrough || v.PreserveMode == CasePreserve {
+				out.WriteString(sym.text)
+			}
+
+			continue
+		}
+
+		equivalent := sym.index - numericSecret[keyPos]
+		keyPos++
+		if equivalent < 0 {
+			equivalent += v.Length
+		}
+
+		out.WriteString(v.restoreCase(v.Alphabets[equivalent], sym.text))
+	}
+
+	return out.String(), nil
+}
@@ -0,0 +1,202 @@
+package vigenere
+
+import (
+	"log"
+	"testing"
+)
+
+func TestNewVigenereUnicodeRejectsEmptyAlphabet(t *testing.T) {
+	if _, err := NewVigenereUnicode(nil); err != errUnicodeAlphabetRequired {
+		t.Errorf("NewVigenereUnicode should reject an empty alphabet. Got: %v.", err)
+	}
+}
+
+func TestNewVigenereUnicodeRejectsDuplicates(t *testing.T) {
+	if _, err := NewVigenereUnicode([]string{"🎉", "🎉"}); err != errDuplicateAlphabets {
+		t.Errorf("NewVigenereUnicode should reject duplicate alphabet entries. Got: %v.", err)
+	}
+}
+
+func TestNewVigenereUnicodeRoundTrip(t *testing.T) {
+	// The first two entries below are multi-rune grapheme clusters (an emoji plus a skin tone
+	// modifier), which NFC normalization does not collapse to a single rune; the third is a
+	// single rune.
+	v, err := NewVigenereUnicode([]string{"👍🏽", "👎🏽", "🎉"})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	plaintext := "👍🏽🎉👎🏽👍🏽"
+
+	secret, err := v.GenerateSecretKey(plaintext)
+	if err != nil {
+		t.Fatalf("GenerateSecretKey should not return an error. Got: %v.", err.Error())
+	}
+
+	ciphertext, err := v.Encrypt(plaintext, secret)
+	if err != nil {
+		t.Fatalf("Encrypt should not return an error. Got: %v.", err.Error())
+	}
+
+	decrypted, err := v.Decrypt(ciphertext, secret)
+	if err != nil {
+		t.Fatalf("Decrypt should not return an error. Got: %v.", err.Error())
+	}
+
+	if decrypted != plaintext {
+		t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", plaintext, decrypted)
+	}
+}
+
+func TestNewVigenereUnicodeNormalizesComposedForms(t *testing.T) {
+	// The alphabet is defined with a decomposed "e" + combining acute accent; ValidateString
+	// should still accept the precomposed form of the same grapheme cluster.
+	v, err := NewVigenereUnicode([]string{"é", "a"})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	valid, err := v.ValidateString("éa")
+	if err != nil {
+		t.Errorf("ValidateString should not return an error for a matching, differently-composed string. Got: %v.", err.Error())
+	}
+
+	if !valid {
+		t.Error("ValidateString should accept a precomposed grapheme cluster matching a decomposed alphabet entry.")
+	}
+}
+
+func TestPassThroughPreservesUnknownCharacters(t *testing.T) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	vigenere.PreserveMode = PassThrough
+
+	plaintext := "HELLO, WORLD!"
+
+	secret, err := vigenere.GenerateSecretKey(plaintext)
+	if err != nil {
+		t.Fatalf("GenerateSecretKey should not return an error. Got: %v.", err.Error())
+	}
+
+	if len(secret) != 10 {
+		t.Errorf("Expected secret to only cover the 10 in-alphabet characters. Got length: %v.", len(secret))
+	}
+
+	ciphertext, err := vigenere.Encrypt(plaintext, secret)
+	if err != nil {
+		t.Fatalf("Encrypt should not return an error. Got: %v.", err.Error())
+	}
+
+	if ciphertext[5:7] != ", " {
+		t.Errorf("Expected punctuation and spacing to pass through unchanged. Got: %v.", ciphertext)
+	}
+
+	decrypted, err := vigenere.Decrypt(ciphertext, secret)
+	if err != nil {
+		t.Fatalf("Decrypt should not return an error. Got: %v.", err.Error())
+	}
+
+	if decrypted != plaintext {
+		t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", plaintext, decrypted)
+	}
+}
+
+func TestCasePreservePassesThroughUnknownCharacters(t *testing.T) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	vigenere.PreserveMode = CasePreserve
+
+	plaintext := "Hello, World!"
+
+	secret, err := vigenere.GenerateSecretKey(plaintext)
+	if err != nil {
+		t.Fatalf("GenerateSecretKey should not return an error. Got: %v.", err.Error())
+	}
+
+	ciphertext, err := vigenere.Encrypt(plaintext, secret)
+	if err != nil {
+		t.Fatalf("Encrypt should not return an error. Got: %v.", err.Error())
+	}
+
+	if ciphertext[5:7] != ", " || ciphertext[len(ciphertext)-1] != '!' {
+		t.Errorf("Expected punctuation and spacing to pass through unchanged. Got: %v.", ciphertext)
+	}
+
+	decrypted, err := vigenere.Decrypt(ciphertext, secret)
+	if err != nil {
+		t.Fatalf("Decrypt should not return an error. Got: %v.", err.Error())
+	}
+
+	if decrypted != plaintext {
+		t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", plaintext, decrypted)
+	}
+}
+
+func TestCasePreserveAcceptsLowercaseSecret(t *testing.T) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	vigenere.PreserveMode = CasePreserve
+
+	plaintext := "HELLOWORLD"
+	secret := "abcdefghij"
+
+	ciphertext, err := vigenere.Encrypt(plaintext, secret)
+	if err != nil {
+		t.Fatalf("Encrypt should not return an error for a lowercase secret. Got: %v.", err.Error())
+	}
+
+	decrypted, err := vigenere.Decrypt(ciphertext, secret)
+	if err != nil {
+		t.Fatalf("Decrypt should not return an error for a lowercase secret. Got: %v.", err.Error())
+	}
+
+	if decrypted != plaintext {
+		t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", plaintext, decrypted)
+	}
+}
+
+func TestCasePreserveRestoresOriginalCase(t *testing.T) {
+	vigenere, err := NewVigenere(nil)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	vigenere.PreserveMode = CasePreserve
+
+	plaintext := "HelloWorld"
+
+	secret, err := vigenere.GenerateSecretKey(plaintext)
+	if err != nil {
+		t.Fatalf("GenerateSecretKey should not return an error. Got: %v.", err.Error())
+	}
+
+	ciphertext, err := vigenere.Encrypt(plaintext, secret)
+	if err != nil {
+		t.Fatalf("Encrypt should not return an error. Got: %v.", err.Error())
+	}
+
+	for i, r := range ciphertext {
+		if isLower(string(r)) != isLower(string([]rune(plaintext)[i])) {
+			t.Errorf("Expected ciphertext casing to mirror plaintext casing at index %v. Got ciphertext: %v, plaintext: %v.", i, ciphertext, plaintext)
+			break
+		}
+	}
+
+	decrypted, err := vigenere.Decrypt(ciphertext, secret)
+	if err != nil {
+		t.Fatalf("Decrypt should not return an error. Got: %v.", err.Error())
+	}
+
+	if decrypted != plaintext {
+		t.Errorf("Expected and actual output are different! Expected: %v. Got: %v.", plaintext, decrypted)
+	}
+}
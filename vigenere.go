@@ -27,6 +27,7 @@ import (
 	"io"
 	"math/big"
 	"strings"
+	"unicode/utf8"
 )
 
 // errDuplicateAlphabets is an error upon using `NewVigenere`, but with duplicate alphabets.
@@ -46,6 +47,43 @@ type Vigenere struct {
 	Alphabets    []string  // List of alphabets or characters to be used for the algorithm. The numerical equivalent of the alphabet is the slice index. Do not input duplicate characters. Data type is not `rune` for interoperability (Go internally uses UTF-8 encoding).
 	Length       int64     // Length of `Alphabets` slice. Automatically created in order to not waste time and space in various methods in which this attribute is used.
 	RandomSource io.Reader // Source of the random number generator. Automatically set to `rand.Reader` in order to use `crypto/rand` module for pseudorandom number generation.
+
+	// PreserveMode controls how Encrypt, Decrypt, ValidateString, and GenerateSecretKey
+	// treat characters that are not part of Alphabets. Defaults to Strict, the original
+	// behavior, if left unset.
+	PreserveMode PreserveMode
+
+	// alphabetIndex maps each single-rune alphabet entry's rune to its slice index, so
+	// `ConvertToNumeric` can look up the numeric equivalent in O(1) instead of
+	// scanning `Alphabets` per character. Built once in `NewVigenere`. Unused when
+	// `unicode` or `multiRune` is true, since alphabet entries may then be more than one rune wide.
+	alphabetIndex map[rune]int64
+
+	// unicode marks instances created via `NewVigenereUnicode`, whose `Alphabets` entries
+	// may be arbitrary grapheme clusters rather than single runes.
+	unicode bool
+
+	// graphemeIndex maps each alphabet entry (NFC-normalized, for `unicode` instances) to
+	// its slice index. Populated by both constructors, and consulted instead of
+	// `alphabetIndex` whenever `unicode` or `multiRune` is true, since `alphabetIndex` alone
+	// cannot distinguish entries that are more than one rune wide.
+	graphemeIndex map[string]int64
+
+	// maxGraphemeLen is the length, in runes, of the longest entry in `Alphabets`. Used to
+	// greedily match the longest possible alphabet entry at each position whenever
+	// `multiRune` or `unicode` is true.
+	maxGraphemeLen int
+
+	// multiRune is true if any entry in `Alphabets` is more than one rune wide, in which
+	// case lookups go through `graphemeIndex` instead of the single-rune `alphabetIndex`.
+	multiRune bool
+
+	// encKey and macKey are only set on instances created via `NewVigenereAEAD`, and back
+	// the `Seal`/`Open` methods: independent subkeys derived from that call's master key via
+	// HKDF, so that `tag`'s HMAC and `deriveSecret`'s HKDF expansion never key off the same
+	// raw secret. Both are left nil for instances created via `NewVigenere`.
+	encKey []byte
+	macKey []byte
 }
 
 // NewVigenere creates a new instance of `Vigenere`, along with its methods. If you desire
@@ -58,19 +96,43 @@ func NewVigenere(alphabets []string) (*Vigenere, error) {
 		alphabets = GenerateDefaultAlphabets()
 	}
 
-	// Ensures the character set does not contain any duplicates.
-	for _, alphabet := range alphabets {
+	// Ensures the character set does not contain any duplicates, and builds the lookups
+	// used by `ConvertToNumeric` along the way: `index` for the common case of single-rune
+	// entries, `graphemeIndex` as a fallback for entries spanning more than one rune (since
+	// keying purely by first rune would otherwise collide, e.g. "AB" and "AC").
+	index := make(map[rune]int64, len(alphabets))
+	graphemeIndex := make(map[string]int64, len(alphabets))
+	maxGraphemeLen := 1
+	multiRune := false
+
+	for i, alphabet := range alphabets {
 		if ok := set[alphabet]; ok {
 			return nil, errDuplicateAlphabets
 		}
 
 		set[alphabet] = true
+		graphemeIndex[alphabet] = int64(i)
+
+		runes := []rune(alphabet)
+		if len(runes) == 1 {
+			index[runes[0]] = int64(i)
+		} else {
+			multiRune = true
+		}
+
+		if len(runes) > maxGraphemeLen {
+			maxGraphemeLen = len(runes)
+		}
 	}
 
 	return &Vigenere{
-		Alphabets:    alphabets,
-		Length:       int64(len(alphabets)),
-		RandomSource: rand.Reader,
+		Alphabets:      alphabets,
+		Length:         int64(len(alphabets)),
+		RandomSource:   rand.Reader,
+		alphabetIndex:  index,
+		graphemeIndex:  graphemeIndex,
+		maxGraphemeLen: maxGraphemeLen,
+		multiRune:      multiRune,
 	}, nil
 }
 
@@ -79,13 +141,24 @@ func NewVigenere(alphabets []string) (*Vigenere, error) {
 func (v *Vigenere) ConvertToNumeric(str string) []int64 {
 	numeric := make([]int64, 0)
 
-	// This iteration costs O(N^2) or quadratic time (exhaustive search).
-	for _, r := range str {
-		for i, char := range v.Alphabets {
-			if char == string(r) {
-				numeric = append(numeric, int64(i))
+	if v.unicode || v.multiRune {
+		// Alphabet entries may be more than one rune wide, so fall back to the greedy
+		// grapheme-cluster tokenizer instead of a single-rune-at-a-time lookup.
+		for _, sym := range v.tokenize(str) {
+			if sym.matched {
+				numeric = append(numeric, sym.index)
 			}
 		}
+
+		return numeric
+	}
+
+	// Lookup is O(1) per character via the precomputed `alphabetIndex`, so the whole
+	// conversion costs O(N) instead of the O(N*|Alphabets|) exhaustive search this used to do.
+	for _, r := range str {
+		if i, ok := v.alphabetIndex[r]; ok {
+			numeric = append(numeric, i)
+		}
 	}
 
 	return numeric
@@ -107,6 +180,13 @@ func (v *Vigenere) ConvertToString(numbers []int64) string {
 // Decrypt decrypts a ciphertext with a secret key. Make sure that the secret key is equal in length with the
 // ciphertext. Returns the plaintext.
 func (v *Vigenere) Decrypt(ciphertext, secret string) (string, error) {
+	// Grapheme-cluster alphabets and the PassThrough/CasePreserve modes need to track
+	// unmatched characters and case alongside the numeric conversion, so they're handled
+	// by a separate, slower path.
+	if v.unicode || v.PreserveMode != Strict {
+		return v.decryptGeneral(ciphertext, secret)
+	}
+
 	// Prepare a slice to hold the plaintext numerical representative.
 	plaintext := make([]int64, 0)
 
@@ -138,6 +218,13 @@ func (v *Vigenere) Decrypt(ciphertext, secret string) (string, error) {
 // Encrypt encrypts a plaintext with a secret key. Make sure that the secret key is equal in length with the
 // plaintext. Returns the ciphertext.
 func (v *Vigenere) Encrypt(plaintext, secret string) (string, error) {
+	// Grapheme-cluster alphabets and the PassThrough/CasePreserve modes need to track
+	// unmatched characters and case alongside the numeric conversion, so they're handled
+	// by a separate, slower path.
+	if v.unicode || v.PreserveMode != Strict {
+		return v.encryptGeneral(plaintext, secret)
+	}
+
 	// Prepare a slice to hold the ciphertext numerical representative.
 	ciphertext := make([]int64, 0)
 
@@ -185,12 +272,15 @@ func (v *Vigenere) GenerateRandomNumber() (int64, error) {
 	return number.Int64(), nil
 }
 
-// GenerateSecretKey generates a secure secret that is equal in length with the plaintext. This is done
-// to ensure the security of the encryption. Complexity is O(N).
+// GenerateSecretKey generates a secure secret long enough to encrypt plaintext under `v`'s
+// current `PreserveMode`: one secret character per plaintext rune in `Strict` mode (per
+// alphabet symbol for instances created via `NewVigenereUnicode`), but only one per
+// in-alphabet character in `PassThrough` and `CasePreserve` modes, since characters copied
+// through don't consume a character of secret. Complexity is O(N).
 func (v *Vigenere) GenerateSecretKey(plaintext string) (string, error) {
 	secret := make([]int64, 0)
 
-	for range plaintext {
+	for i := 0; i < v.keyLength(plaintext); i++ {
 		number, err := v.GenerateRandomNumber()
 		if err != nil {
 			return "", err
@@ -202,10 +292,43 @@ func (v *Vigenere) GenerateSecretKey(plaintext string) (string, error) {
 	return v.ConvertToString(secret), nil
 }
 
+// keyLength reports how many secret characters are needed to encrypt plaintext under `v`'s
+// current `PreserveMode`.
+func (v *Vigenere) keyLength(plaintext string) int {
+	if v.PreserveMode == Strict && !v.unicode {
+		return utf8.RuneCountInString(plaintext)
+	}
+
+	count := 0
+	for _, sym := range v.tokenize(plaintext) {
+		if sym.matched {
+			count++
+		}
+	}
+
+	return count
+}
+
 // ValidateString validates a string whether it conforms to the required alphabets or not. The process
 // transforms the `Alphabets` slice into a hash map / object / map, and then checks the availability of
 // each letters.
 func (v *Vigenere) ValidateString(str string) (bool, error) {
+	// PassThrough and CasePreserve are designed to accept any input: out-of-alphabet
+	// characters are either copied through verbatim or matched case-insensitively.
+	if v.PreserveMode != Strict {
+		return true, nil
+	}
+
+	if v.unicode {
+		for _, sym := range v.tokenize(str) {
+			if !sym.matched {
+				return false, errInvalidString
+			}
+		}
+
+		return true, nil
+	}
+
 	set := make(map[string]bool)
 
 	// Building this map/object/hash table costs O(N) time.
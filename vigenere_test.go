@@ -192,6 +192,33 @@ func TestNewVigenere(t *testing.T) {
 	})
 }
 
+func TestConvertToNumericMultiRuneAlphabetEntries(t *testing.T) {
+	// Alphabet entries wider than one rune that share a first rune ("AB" and "AC") must not
+	// collide in the numeric lookup: a bare "A" by itself is not a valid entry and must not
+	// resolve to either of them.
+	vigenere, err := NewVigenere([]string{"AB", "AC", "X"})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	if numeric := vigenere.ConvertToNumeric("A"); len(numeric) != 0 {
+		t.Errorf("Expected 'A' alone not to match any alphabet entry. Got: %v.", numeric)
+	}
+
+	numeric := vigenere.ConvertToNumeric("ABACX")
+	expected := []int64{0, 1, 2}
+	if len(numeric) != len(expected) {
+		t.Fatalf("Expected %v. Got: %v.", expected, numeric)
+	}
+
+	for i, n := range numeric {
+		if n != expected[i] {
+			t.Errorf("Expected %v. Got: %v.", expected, numeric)
+			break
+		}
+	}
+}
+
 func TestRandomNumberGenerationFailure(t *testing.T) {
 	vigenere := &Vigenere{
 		Alphabets:    GenerateDefaultAlphabets(),